@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/aws/aws-lambda-go/lambda"
 
 	"lambda-func/pkg/handlers"
+	"lambda-func/pkg/middleware"
 	"lambda-func/pkg/stores"
 )
 
@@ -16,6 +18,8 @@ func main() {
 		log.Fatalf("Failed to initialize health log store: %v", err)
 	}
 
-	handler := handlers.NewHealthLogHandler(store)
+	verifier := middleware.NewJWKSVerifier(os.Getenv("HEALTH_LOG_JWKS_URL"))
+
+	handler := handlers.NewHealthLogHandler(store, verifier)
 	lambda.Start(handler.HandleRequest)
 }
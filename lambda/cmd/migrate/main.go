@@ -0,0 +1,131 @@
+// Command migrate creates the health-logs DynamoDB table described by
+// migrations/health-logs.json if it does not already exist. It is intended
+// for local development against LocalStack, where there is no CDK/CloudFormation
+// stack to provision the table.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"lambda-func/pkg/stores"
+)
+
+// tableSchema mirrors the shape of migrations/health-logs.json.
+type tableSchema struct {
+	TableName              string                 `json:"tableName"`
+	BillingMode            string                 `json:"billingMode"`
+	AttributeDefinitions   []attributeDefinition  `json:"attributeDefinitions"`
+	KeySchema              []keySchemaElement     `json:"keySchema"`
+	GlobalSecondaryIndexes []globalSecondaryIndex `json:"globalSecondaryIndexes"`
+}
+
+type attributeDefinition struct {
+	AttributeName string `json:"attributeName"`
+	AttributeType string `json:"attributeType"`
+}
+
+type keySchemaElement struct {
+	AttributeName string `json:"attributeName"`
+	KeyType       string `json:"keyType"`
+}
+
+type globalSecondaryIndex struct {
+	IndexName string             `json:"indexName"`
+	KeySchema []keySchemaElement `json:"keySchema"`
+	Projection struct {
+		ProjectionType string `json:"projectionType"`
+	} `json:"projection"`
+}
+
+func main() {
+	migrationPath := "migrations/health-logs.json"
+	if len(os.Args) > 1 {
+		migrationPath = os.Args[1]
+	}
+
+	raw, err := os.ReadFile(migrationPath)
+	if err != nil {
+		log.Fatalf("failed to read migration file %s: %v", migrationPath, err)
+	}
+
+	var schema tableSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		log.Fatalf("failed to parse migration file %s: %v", migrationPath, err)
+	}
+
+	if tableName := os.Getenv("DYNAMODB_TABLE_NAME"); tableName != "" {
+		schema.TableName = tableName
+	}
+
+	ctx := context.Background()
+	client, err := stores.NewDynamoDBClient(ctx)
+	if err != nil {
+		log.Fatalf("failed to build DynamoDB client: %v", err)
+	}
+
+	if err := createTableIfNotExists(ctx, client, schema); err != nil {
+		log.Fatalf("failed to migrate table %s: %v", schema.TableName, err)
+	}
+
+	log.Printf("table %s is up to date", schema.TableName)
+}
+
+func createTableIfNotExists(ctx context.Context, client *dynamodb.Client, schema tableSchema) error {
+	_, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(schema.TableName),
+	})
+	if err == nil {
+		return nil
+	}
+
+	var notFound *types.ResourceNotFoundException
+	if !errors.As(err, &notFound) {
+		return err
+	}
+
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(schema.TableName),
+		BillingMode: types.BillingMode(schema.BillingMode),
+	}
+
+	for _, attr := range schema.AttributeDefinitions {
+		input.AttributeDefinitions = append(input.AttributeDefinitions, types.AttributeDefinition{
+			AttributeName: aws.String(attr.AttributeName),
+			AttributeType: types.ScalarAttributeType(attr.AttributeType),
+		})
+	}
+
+	for _, key := range schema.KeySchema {
+		input.KeySchema = append(input.KeySchema, types.KeySchemaElement{
+			AttributeName: aws.String(key.AttributeName),
+			KeyType:       types.KeyType(key.KeyType),
+		})
+	}
+
+	for _, gsi := range schema.GlobalSecondaryIndexes {
+		index := types.GlobalSecondaryIndex{
+			IndexName: aws.String(gsi.IndexName),
+			Projection: &types.Projection{
+				ProjectionType: types.ProjectionType(gsi.Projection.ProjectionType),
+			},
+		}
+		for _, key := range gsi.KeySchema {
+			index.KeySchema = append(index.KeySchema, types.KeySchemaElement{
+				AttributeName: aws.String(key.AttributeName),
+				KeyType:       types.KeyType(key.KeyType),
+			})
+		}
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, index)
+	}
+
+	_, err = client.CreateTable(ctx, input)
+	return err
+}
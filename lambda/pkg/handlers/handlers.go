@@ -3,32 +3,87 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 
+	"lambda-func/pkg/middleware"
 	"lambda-func/pkg/models"
 	"lambda-func/pkg/stores"
+	"lambda-func/pkg/validators"
 )
 
+// defaultListLimit and maxListLimit bound the page size for GET /health-logs
+// so a caller can never trigger an unbounded table scan.
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// maxBatchCreateLogs caps a single POST /health-logs/batch request, in line
+// with DynamoDB BatchWriteItem's own limits scaled up for chunking.
+const maxBatchCreateLogs = 500
+
 type HealthLogHandler struct {
-	store *stores.HealthLogStore
+	store    stores.HealthLogStorer
+	verifier middleware.Verifier
 }
 
-func NewHealthLogHandler(store *stores.HealthLogStore) *HealthLogHandler {
+func NewHealthLogHandler(store stores.HealthLogStorer, verifier middleware.Verifier) *HealthLogHandler {
 	return &HealthLogHandler{
-		store: store,
+		store:    store,
+		verifier: verifier,
 	}
 }
 
 // CreateHealthLogRequest represents the request body for creating a health log
 type CreateHealthLogRequest struct {
-	UserID string `json:"userId"`
-	Type   string `json:"type"`
-	Value  string `json:"value"`
+	UserID string          `json:"userId"`
+	Type   string          `json:"type"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// UnprocessableResponse is returned when a health log's type isn't one of
+// the registered schemas.
+type UnprocessableResponse struct {
+	Error           string              `json:"error"`
+	Message         string              `json:"message"`
+	AcceptedSchemas []validators.Schema `json:"acceptedSchemas"`
+}
+
+// BatchCreateHealthLogRequest represents the request body for POST /health-logs/batch
+type BatchCreateHealthLogRequest struct {
+	Logs []BatchHealthLogEntry `json:"logs"`
+}
+
+// BatchHealthLogEntry is a single log within a batch ingestion request. Timestamp
+// is optional; if omitted, the server assigns one.
+type BatchHealthLogEntry struct {
+	UserID    string          `json:"userId"`
+	Type      string          `json:"type"`
+	Value     json.RawMessage `json:"value"`
+	Timestamp string          `json:"timestamp,omitempty"`
+}
+
+// PatchHealthLogRequest represents the request body for partially updating a health log
+type PatchHealthLogRequest struct {
+	UserID    string                 `json:"userId"`
+	Timestamp string                 `json:"timestamp"`
+	Fields    map[string]interface{} `json:"fields"`
 }
 
+// immutablePatchFields cannot be set via PatchHealthLogRequest.Fields:
+// userId/timestamp are the primary key, version is the
+// optimistic-concurrency counter PatchHealthLog increments itself, and
+// unit is derived from (type, value) by the validators registry rather
+// than settable directly.
+var immutablePatchFields = []string{"userId", "timestamp", "version", "unit"}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -37,9 +92,10 @@ type ErrorResponse struct {
 
 // SuccessResponse represents a success response
 type SuccessResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Message string      `json:"message,omitempty"`
+	Success    bool        `json:"success"`
+	Data       interface{} `json:"data,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	NextCursor string      `json:"nextCursor,omitempty"`
 }
 
 // HandleRequest is the main entry point for Lambda requests
@@ -49,7 +105,7 @@ func (h *HealthLogHandler) HandleRequest(ctx context.Context, request events.API
 		"Content-Type":                 "application/json",
 		"Access-Control-Allow-Origin":  "*",
 		"Access-Control-Allow-Headers": "Content-Type,Authorization",
-		"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
+		"Access-Control-Allow-Methods": "GET,POST,PATCH,DELETE,OPTIONS",
 	}
 
 	// Handle preflight requests
@@ -61,21 +117,73 @@ func (h *HealthLogHandler) HandleRequest(ctx context.Context, request events.API
 		}, nil
 	}
 
+	// Every route below acts on a specific userId, so authenticate the
+	// caller once up front and let each handler authorize against it.
+	claims, errResp := h.authenticate(ctx, request, headers)
+	if errResp != nil {
+		return *errResp, nil
+	}
+
 	// Route based on HTTP method and path
 	switch request.HTTPMethod {
 	case "POST":
-		return h.handleCreateHealthLog(ctx, request, headers)
+		if strings.HasSuffix(request.Path, "/batch") {
+			return h.handleBatchCreateHealthLog(ctx, request, headers, claims)
+		}
+		return h.handleCreateHealthLog(ctx, request, headers, claims)
 	case "GET":
-		return h.handleGetHealthLogs(ctx, request, headers)
+		if strings.HasSuffix(request.Path, "/aggregate") {
+			return h.handleAggregateHealthLogs(ctx, request, headers, claims)
+		}
+		return h.handleGetHealthLogs(ctx, request, headers, claims)
+	case "PATCH":
+		return h.handlePatchHealthLog(ctx, request, headers, claims)
 	case "DELETE":
-		return h.handleDeleteHealthLog(ctx, request, headers)
+		return h.handleDeleteHealthLog(ctx, request, headers, claims)
 	default:
 		return h.errorResponse(http.StatusMethodNotAllowed, "Method not allowed", "", headers), nil
 	}
 }
 
+// authenticate extracts and verifies the caller's bearer token, returning an
+// error response to short-circuit HandleRequest if it's missing or invalid.
+func (h *HealthLogHandler) authenticate(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string) (*middleware.Claims, *events.APIGatewayProxyResponse) {
+	token := bearerToken(request.Headers["Authorization"])
+	if token == "" {
+		resp := h.errorResponse(http.StatusUnauthorized, "Unauthorized", "missing bearer token", headers)
+		return nil, &resp
+	}
+
+	claims, err := h.verifier.Verify(ctx, token)
+	if err != nil {
+		resp := h.errorResponse(http.StatusUnauthorized, "Unauthorized", err.Error(), headers)
+		return nil, &resp
+	}
+
+	return claims, nil
+}
+
+// bearerToken extracts the token from a "Bearer <token>" Authorization header.
+func bearerToken(header string) string {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// authorizeUserID enforces that the caller identified by claims may act on
+// userID: either they are that user, or they hold the "admin" scope.
+func (h *HealthLogHandler) authorizeUserID(claims *middleware.Claims, userID string, headers map[string]string) *events.APIGatewayProxyResponse {
+	if claims.Subject == userID || claims.HasScope("admin") {
+		return nil
+	}
+	resp := h.errorResponse(http.StatusForbidden, "Forbidden", "you may not access another user's health logs", headers)
+	return &resp
+}
+
 // handleCreateHealthLog creates a new health log
-func (h *HealthLogHandler) handleCreateHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string) (events.APIGatewayProxyResponse, error) {
+func (h *HealthLogHandler) handleCreateHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
 	var req CreateHealthLogRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
 		return h.errorResponse(http.StatusBadRequest, "Invalid request body", err.Error(), headers), nil
@@ -85,19 +193,33 @@ func (h *HealthLogHandler) handleCreateHealthLog(ctx context.Context, request ev
 	if req.UserID == "" {
 		return h.errorResponse(http.StatusBadRequest, "Missing required field", "userId is required", headers), nil
 	}
+	if resp := h.authorizeUserID(claims, req.UserID, headers); resp != nil {
+		return *resp, nil
+	}
 	if req.Type == "" {
 		return h.errorResponse(http.StatusBadRequest, "Missing required field", "type is required", headers), nil
 	}
-	if req.Value == "" {
+	if len(req.Value) == 0 {
 		return h.errorResponse(http.StatusBadRequest, "Missing required field", "value is required", headers), nil
 	}
 
+	logType := models.Type(req.Type)
+	if !validators.IsRegistered(logType) {
+		return h.unprocessableResponse(headers), nil
+	}
+
+	unit, err := validators.Validate(logType, req.Value)
+	if err != nil {
+		return h.errorResponse(http.StatusBadRequest, "Invalid value", err.Error(), headers), nil
+	}
+
 	// Create health log with current timestamp
 	healthLog := &models.HealthLog{
 		UserID:    req.UserID,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Type:      req.Type,
-		Value:     req.Value,
+		Type:      logType,
+		Value:     models.RawValue(req.Value),
+		Unit:      unit,
 	}
 
 	if err := h.store.CreateHealthLog(ctx, healthLog); err != nil {
@@ -107,12 +229,86 @@ func (h *HealthLogHandler) handleCreateHealthLog(ctx context.Context, request ev
 	return h.successResponse(http.StatusCreated, healthLog, "Health log created successfully", headers), nil
 }
 
+// handleBatchCreateHealthLog ingests a batch of health logs in one request,
+// which wearables and phone syncs typically need to upload backlogged
+// samples without one round trip per sample.
+func (h *HealthLogHandler) handleBatchCreateHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
+	var req BatchCreateHealthLogRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return h.errorResponse(http.StatusBadRequest, "Invalid request body", err.Error(), headers), nil
+	}
+
+	if len(req.Logs) == 0 {
+		return h.errorResponse(http.StatusBadRequest, "Missing required field", "logs must be a non-empty array", headers), nil
+	}
+	if len(req.Logs) > maxBatchCreateLogs {
+		return h.errorResponse(http.StatusBadRequest, "Too many logs", fmt.Sprintf("logs cannot exceed %d entries per request", maxBatchCreateLogs), headers), nil
+	}
+
+	now := time.Now().UTC()
+	healthLogs := make([]models.HealthLog, len(req.Logs))
+	for i, entry := range req.Logs {
+		if entry.UserID == "" {
+			return h.errorResponse(http.StatusBadRequest, "Missing required field", fmt.Sprintf("logs[%d].userId is required", i), headers), nil
+		}
+		if resp := h.authorizeUserID(claims, entry.UserID, headers); resp != nil {
+			return *resp, nil
+		}
+		if entry.Type == "" {
+			return h.errorResponse(http.StatusBadRequest, "Missing required field", fmt.Sprintf("logs[%d].type is required", i), headers), nil
+		}
+		if len(entry.Value) == 0 {
+			return h.errorResponse(http.StatusBadRequest, "Missing required field", fmt.Sprintf("logs[%d].value is required", i), headers), nil
+		}
+
+		logType := models.Type(entry.Type)
+		if !validators.IsRegistered(logType) {
+			return h.unprocessableResponse(headers), nil
+		}
+
+		unit, err := validators.Validate(logType, entry.Value)
+		if err != nil {
+			return h.errorResponse(http.StatusBadRequest, "Invalid value", fmt.Sprintf("logs[%d]: %s", i, err.Error()), headers), nil
+		}
+
+		timestamp := entry.Timestamp
+		if timestamp == "" {
+			// Nanosecond precision so auto-assigned timestamps within the
+			// same batch don't collide on the userId+timestamp key.
+			timestamp = now.Add(time.Duration(i) * time.Nanosecond).Format(time.RFC3339Nano)
+		} else if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			// ListHealthLogs' range filter and Aggregate's bucketing both
+			// assume RFC3339 timestamps; a caller-supplied one that doesn't
+			// parse would otherwise be stored but silently break both.
+			return h.errorResponse(http.StatusBadRequest, "Invalid field", fmt.Sprintf("logs[%d].timestamp must be RFC3339", i), headers), nil
+		}
+
+		healthLogs[i] = models.HealthLog{
+			UserID:    entry.UserID,
+			Timestamp: timestamp,
+			Type:      logType,
+			Value:     models.RawValue(entry.Value),
+			Unit:      unit,
+		}
+	}
+
+	results, err := h.store.BatchCreateHealthLogs(ctx, healthLogs)
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "Failed to batch create health logs", err.Error(), headers), nil
+	}
+
+	return h.successResponse(http.StatusOK, results, "Batch processed", headers), nil
+}
+
 // handleGetHealthLogs retrieves health logs based on query parameters
-func (h *HealthLogHandler) handleGetHealthLogs(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string) (events.APIGatewayProxyResponse, error) {
+func (h *HealthLogHandler) handleGetHealthLogs(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
 	userID := request.QueryStringParameters["userId"]
 	if userID == "" {
 		return h.errorResponse(http.StatusBadRequest, "Missing required parameter", "userId is required", headers), nil
 	}
+	if resp := h.authorizeUserID(claims, userID, headers); resp != nil {
+		return *resp, nil
+	}
 
 	logType := request.QueryStringParameters["type"]
 	timestamp := request.QueryStringParameters["timestamp"]
@@ -121,7 +317,7 @@ func (h *HealthLogHandler) handleGetHealthLogs(ctx context.Context, request even
 	if timestamp != "" {
 		healthLog, err := h.store.GetHealthLog(ctx, userID, timestamp)
 		if err != nil {
-			if err.Error() == "health log not found" {
+			if errors.Is(err, stores.ErrHealthLogNotFound) {
 				return h.errorResponse(http.StatusNotFound, "Health log not found", "", headers), nil
 			}
 			return h.errorResponse(http.StatusInternalServerError, "Failed to get health log", err.Error(), headers), nil
@@ -129,32 +325,227 @@ func (h *HealthLogHandler) handleGetHealthLogs(ctx context.Context, request even
 		return h.successResponse(http.StatusOK, healthLog, "", headers), nil
 	}
 
-	// If type is provided, get health logs filtered by type
-	if logType != "" {
-		healthLogs, err := h.store.GetHealthLogsByUserIDAndType(ctx, userID, logType)
-		if err != nil {
-			return h.errorResponse(http.StatusInternalServerError, "Failed to get health logs", err.Error(), headers), nil
+	// Otherwise list health logs, optionally filtered by type and time range,
+	// and paginated via limit/cursor.
+	limit := defaultListLimit
+	if raw := request.QueryStringParameters["limit"]; raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "limit must be a positive integer", headers), nil
 		}
-		return h.successResponse(http.StatusOK, healthLogs, "", headers), nil
+		limit = parsed
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	from := request.QueryStringParameters["from"]
+	to := request.QueryStringParameters["to"]
+	if (from == "") != (to == "") {
+		return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "from and to must both be set or both be omitted", headers), nil
+	}
+
+	query := stores.ListHealthLogsQuery{
+		UserID: userID,
+		Type:   logType,
+		From:   from,
+		To:     to,
+		Limit:  int32(limit),
+		Cursor: request.QueryStringParameters["cursor"],
 	}
 
-	// Get all health logs for user
-	healthLogs, err := h.store.GetHealthLogsByUserID(ctx, userID)
+	healthLogs, nextCursor, err := h.store.ListHealthLogs(ctx, query)
 	if err != nil {
+		if errors.Is(err, stores.ErrInvalidCursor) {
+			return h.errorResponse(http.StatusBadRequest, "Invalid parameter", err.Error(), headers), nil
+		}
 		return h.errorResponse(http.StatusInternalServerError, "Failed to get health logs", err.Error(), headers), nil
 	}
 
-	return h.successResponse(http.StatusOK, healthLogs, "", headers), nil
+	return h.paginatedResponse(http.StatusOK, healthLogs, nextCursor, headers), nil
+}
+
+// validAggregateBuckets and validAggregateOps are the accepted values for
+// the bucket and op query parameters on GET /health-logs/aggregate.
+var (
+	validAggregateBuckets = map[string]bool{"day": true, "week": true, "month": true}
+	validAggregateOps     = map[string]bool{"avg": true, "min": true, "max": true, "sum": true, "count": true}
+)
+
+// handleAggregateHealthLogs returns day/week/month rollups of a user's
+// health logs of one type, since most health-tracker consumers want charts
+// rather than raw rows.
+func (h *HealthLogHandler) handleAggregateHealthLogs(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
+	userID := request.QueryStringParameters["userId"]
+	if userID == "" {
+		return h.errorResponse(http.StatusBadRequest, "Missing required parameter", "userId is required", headers), nil
+	}
+	if resp := h.authorizeUserID(claims, userID, headers); resp != nil {
+		return *resp, nil
+	}
+
+	logType := request.QueryStringParameters["type"]
+	if logType == "" {
+		return h.errorResponse(http.StatusBadRequest, "Missing required parameter", "type is required", headers), nil
+	}
+
+	bucket := request.QueryStringParameters["bucket"]
+	if !validAggregateBuckets[bucket] {
+		return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "bucket must be one of day, week, month", headers), nil
+	}
+
+	// op is validated so typos are rejected early; every bucket reports all
+	// five aggregates regardless of op, matching the response shape below.
+	op := request.QueryStringParameters["op"]
+	if op == "" {
+		op = "avg"
+	}
+	if !validAggregateOps[op] {
+		return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "op must be one of avg, min, max, sum, count", headers), nil
+	}
+
+	tzName := request.QueryStringParameters["tz"]
+	if tzName == "" {
+		tzName = "UTC"
+	}
+	tz, err := time.LoadLocation(tzName)
+	if err != nil {
+		return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "tz must be a valid IANA timezone", headers), nil
+	}
+
+	from := request.QueryStringParameters["from"]
+	to := request.QueryStringParameters["to"]
+	if (from == "") != (to == "") {
+		return h.errorResponse(http.StatusBadRequest, "Invalid parameter", "from and to must both be set or both be omitted", headers), nil
+	}
+
+	buckets, err := h.store.Aggregate(ctx, stores.AggregateQuery{
+		UserID: userID,
+		Type:   logType,
+		Bucket: bucket,
+		From:   from,
+		To:     to,
+		TZ:     tz,
+	})
+	if err != nil {
+		return h.errorResponse(http.StatusInternalServerError, "Failed to aggregate health logs", err.Error(), headers), nil
+	}
+
+	return h.successResponse(http.StatusOK, buckets, "", headers), nil
+}
+
+// handlePatchHealthLog partially updates an existing health log in place,
+// rather than replacing the whole record
+func (h *HealthLogHandler) handlePatchHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
+	var req PatchHealthLogRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return h.errorResponse(http.StatusBadRequest, "Invalid request body", err.Error(), headers), nil
+	}
+
+	if req.UserID == "" {
+		return h.errorResponse(http.StatusBadRequest, "Missing required field", "userId is required", headers), nil
+	}
+	if resp := h.authorizeUserID(claims, req.UserID, headers); resp != nil {
+		return *resp, nil
+	}
+	if req.Timestamp == "" {
+		return h.errorResponse(http.StatusBadRequest, "Missing required field", "timestamp is required", headers), nil
+	}
+	if len(req.Fields) == 0 {
+		return h.errorResponse(http.StatusBadRequest, "Missing required field", "fields is required", headers), nil
+	}
+	for _, immutable := range immutablePatchFields {
+		if _, ok := req.Fields[immutable]; ok {
+			return h.errorResponse(http.StatusBadRequest, "Invalid field", fmt.Sprintf("%s cannot be patched", immutable), headers), nil
+		}
+	}
+
+	var expectedVersion *int64
+	if ifMatch := request.Headers["If-Match"]; ifMatch != "" {
+		version, err := strconv.ParseInt(ifMatch, 10, 64)
+		if err != nil {
+			return h.errorResponse(http.StatusBadRequest, "Invalid header", "If-Match must be an integer version", headers), nil
+		}
+		expectedVersion = &version
+	}
+
+	// A patch that touches type or value can break that type's schema
+	// (e.g. a heart_rate out of range, or a type change that no longer
+	// matches the stored value's shape), so re-validate the same way
+	// create does and recompute the canonical unit alongside it.
+	_, hasType := req.Fields["type"]
+	_, hasValue := req.Fields["value"]
+	if hasType || hasValue {
+		current, err := h.store.GetHealthLog(ctx, req.UserID, req.Timestamp)
+		if err != nil {
+			if errors.Is(err, stores.ErrHealthLogNotFound) {
+				return h.errorResponse(http.StatusNotFound, "Health log not found", "", headers), nil
+			}
+			return h.errorResponse(http.StatusInternalServerError, "Failed to get health log", err.Error(), headers), nil
+		}
+
+		logType := current.Type
+		if hasType {
+			typeStr, ok := req.Fields["type"].(string)
+			if !ok {
+				return h.errorResponse(http.StatusBadRequest, "Invalid field", "type must be a string", headers), nil
+			}
+			logType = models.Type(typeStr)
+			if !validators.IsRegistered(logType) {
+				return h.unprocessableResponse(headers), nil
+			}
+		}
+
+		rawValue := json.RawMessage(current.Value)
+		if hasValue {
+			encoded, err := json.Marshal(req.Fields["value"])
+			if err != nil {
+				return h.errorResponse(http.StatusBadRequest, "Invalid field", "value must be valid JSON", headers), nil
+			}
+			rawValue = encoded
+		}
+
+		unit, err := validators.Validate(logType, rawValue)
+		if err != nil {
+			return h.errorResponse(http.StatusBadRequest, "Invalid value", err.Error(), headers), nil
+		}
+		req.Fields["unit"] = unit
+
+		// Pin the write to the version just validated against, so a
+		// concurrent patch landing in between can't leave this request's
+		// unit validated against a type/value that's already stale by the
+		// time PatchHealthLog actually applies it. An explicit If-Match
+		// from the caller is left as-is and still enforced as before.
+		if expectedVersion == nil {
+			expectedVersion = &current.Version
+		}
+	}
+
+	healthLog, err := h.store.PatchHealthLog(ctx, req.UserID, req.Timestamp, req.Fields, expectedVersion)
+	if err != nil {
+		if errors.Is(err, stores.ErrHealthLogNotFound) {
+			return h.errorResponse(http.StatusNotFound, "Health log not found", "", headers), nil
+		}
+		if errors.Is(err, stores.ErrVersionMismatch) {
+			return h.errorResponse(http.StatusPreconditionFailed, "Version mismatch", err.Error(), headers), nil
+		}
+		return h.errorResponse(http.StatusInternalServerError, "Failed to update health log", err.Error(), headers), nil
+	}
+
+	return h.successResponse(http.StatusOK, healthLog, "Health log updated successfully", headers), nil
 }
 
 // handleDeleteHealthLog deletes a specific health log
-func (h *HealthLogHandler) handleDeleteHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string) (events.APIGatewayProxyResponse, error) {
+func (h *HealthLogHandler) handleDeleteHealthLog(ctx context.Context, request events.APIGatewayProxyRequest, headers map[string]string, claims *middleware.Claims) (events.APIGatewayProxyResponse, error) {
 	userID := request.QueryStringParameters["userId"]
 	timestamp := request.QueryStringParameters["timestamp"]
 
 	if userID == "" || timestamp == "" {
 		return h.errorResponse(http.StatusBadRequest, "Missing required parameters", "userId and timestamp are required", headers), nil
 	}
+	if resp := h.authorizeUserID(claims, userID, headers); resp != nil {
+		return *resp, nil
+	}
 
 	if err := h.store.DeleteHealthLog(ctx, userID, timestamp); err != nil {
 		return h.errorResponse(http.StatusInternalServerError, "Failed to delete health log", err.Error(), headers), nil
@@ -163,6 +554,40 @@ func (h *HealthLogHandler) handleDeleteHealthLog(ctx context.Context, request ev
 	return h.successResponse(http.StatusOK, nil, "Health log deleted successfully", headers), nil
 }
 
+// paginatedResponse creates a success response carrying a NextCursor for
+// callers to pass back in to continue listing.
+func (h *HealthLogHandler) paginatedResponse(statusCode int, data interface{}, nextCursor string, headers map[string]string) events.APIGatewayProxyResponse {
+	response := SuccessResponse{
+		Success:    true,
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+
+	body, _ := json.Marshal(response)
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       string(body),
+	}
+}
+
+// unprocessableResponse creates a 422 response listing the accepted value
+// schemas, for a health log submitted with an unknown type.
+func (h *HealthLogHandler) unprocessableResponse(headers map[string]string) events.APIGatewayProxyResponse {
+	response := UnprocessableResponse{
+		Error:           "Unknown health log type",
+		Message:         "type must be one of the accepted schemas",
+		AcceptedSchemas: validators.Schemas(),
+	}
+
+	body, _ := json.Marshal(response)
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusUnprocessableEntity,
+		Headers:    headers,
+		Body:       string(body),
+	}
+}
+
 // errorResponse creates a standardized error response
 func (h *HealthLogHandler) errorResponse(statusCode int, error, message string, headers map[string]string) events.APIGatewayProxyResponse {
 	response := ErrorResponse{
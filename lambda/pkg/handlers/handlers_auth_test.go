@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang-jwt/jwt/v5"
+
+	"lambda-func/pkg/middleware"
+	"lambda-func/pkg/stores"
+)
+
+func TestHandleRequest_MissingBearerTokenIsUnauthorized(t *testing.T) {
+	h := NewHealthLogHandler(stores.NewMemoryHealthLogStore(), &middleware.StaticVerifier{
+		Claims: &middleware.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}},
+	})
+
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/health-logs",
+		QueryStringParameters: map[string]string{"userId": "user-1"},
+	}
+
+	resp, err := h.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestHandleRequest_RejectedTokenIsUnauthorized(t *testing.T) {
+	h := NewHealthLogHandler(stores.NewMemoryHealthLogStore(), &middleware.StaticVerifier{
+		Err: errors.New("token expired"),
+	})
+
+	req := authedRequest("GET", "/health-logs", "")
+	req.QueryStringParameters = map[string]string{"userId": "user-1"}
+
+	resp, err := h.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestHandleRequest_OtherUsersLogsAreForbidden(t *testing.T) {
+	h := newTestHandler("user-1")
+
+	req := authedRequest("GET", "/health-logs", "")
+	req.QueryStringParameters = map[string]string{"userId": "someone-else"}
+
+	resp, err := h.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestHandleRequest_AdminScopeMayAccessOtherUsersLogs(t *testing.T) {
+	h := NewHealthLogHandler(stores.NewMemoryHealthLogStore(), &middleware.StaticVerifier{
+		Claims: &middleware.Claims{
+			Scope:            "admin",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "support-agent"},
+		},
+	})
+
+	req := authedRequest("GET", "/health-logs", "")
+	req.QueryStringParameters = map[string]string{"userId": "someone-else"}
+
+	resp, err := h.HandleRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang-jwt/jwt/v5"
+
+	"lambda-func/pkg/middleware"
+	"lambda-func/pkg/models"
+	"lambda-func/pkg/stores"
+)
+
+// successEnvelope mirrors SuccessResponse but keeps Data raw so tests can
+// unmarshal it into whatever concrete type the endpoint under test returns.
+type successEnvelope struct {
+	Success    bool            `json:"success"`
+	Data       json.RawMessage `json:"data"`
+	Message    string          `json:"message"`
+	NextCursor string          `json:"nextCursor"`
+}
+
+// newTestHandler builds a handler backed by MemoryHealthLogStore and a
+// StaticVerifier that authenticates every request as subject, so these
+// tests exercise the handler layer without any AWS dependency.
+func newTestHandler(subject string) *HealthLogHandler {
+	return NewHealthLogHandler(stores.NewMemoryHealthLogStore(), &middleware.StaticVerifier{
+		Claims: &middleware.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}},
+	})
+}
+
+func authedRequest(method, path, body string) events.APIGatewayProxyRequest {
+	return events.APIGatewayProxyRequest{
+		HTTPMethod: method,
+		Path:       path,
+		Headers:    map[string]string{"Authorization": "Bearer test"},
+		Body:       body,
+	}
+}
+
+func decodeEnvelope(t *testing.T, resp events.APIGatewayProxyResponse) successEnvelope {
+	t.Helper()
+	var env successEnvelope
+	if err := json.Unmarshal([]byte(resp.Body), &env); err != nil {
+		t.Fatalf("failed to decode response body %q: %v", resp.Body, err)
+	}
+	return env
+}
+
+func TestHandlePatchHealthLog_SetAndRemoveFields(t *testing.T) {
+	h := newTestHandler("user-1")
+	ctx := context.Background()
+
+	createResp, err := h.HandleRequest(ctx, authedRequest("POST", "/health-logs", `{"userId":"user-1","type":"weight","value":70}`))
+	if err != nil || createResp.StatusCode != http.StatusCreated {
+		t.Fatalf("create failed: status=%d err=%v body=%s", createResp.StatusCode, err, createResp.Body)
+	}
+
+	var created models.HealthLog
+	if err := json.Unmarshal(decodeEnvelope(t, createResp).Data, &created); err != nil {
+		t.Fatalf("failed to decode created log: %v", err)
+	}
+
+	// "notes" isn't a real HealthLog field; patching it alongside "value"
+	// exercises the same simultaneous SET+REMOVE path as a real field
+	// removal would, without relying on "unit" (now immutable) or any
+	// other bookkeeping field.
+	patchBody, err := json.Marshal(map[string]interface{}{
+		"userId":    "user-1",
+		"timestamp": created.Timestamp,
+		"fields": map[string]interface{}{
+			"notes": nil,
+			"value": json.RawMessage(`80`),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build patch body: %v", err)
+	}
+
+	patchResp, err := h.HandleRequest(ctx, authedRequest("PATCH", "/health-logs", string(patchBody)))
+	if err != nil || patchResp.StatusCode != http.StatusOK {
+		t.Fatalf("patch failed: status=%d err=%v body=%s", patchResp.StatusCode, err, patchResp.Body)
+	}
+
+	var patched models.HealthLog
+	if err := json.Unmarshal(decodeEnvelope(t, patchResp).Data, &patched); err != nil {
+		t.Fatalf("failed to decode patched log: %v", err)
+	}
+
+	if string(patched.Value) != "80" {
+		t.Errorf("expected value to be set to 80, got %s", patched.Value)
+	}
+	if patched.Unit != "kg" {
+		t.Errorf("expected unit to stay the validated kg unit, got %q", patched.Unit)
+	}
+}
+
+func TestHandlePatchHealthLog_RejectsImmutableFields(t *testing.T) {
+	h := newTestHandler("user-1")
+	ctx := context.Background()
+
+	createResp, err := h.HandleRequest(ctx, authedRequest("POST", "/health-logs", `{"userId":"user-1","type":"weight","value":70}`))
+	if err != nil || createResp.StatusCode != http.StatusCreated {
+		t.Fatalf("create failed: status=%d err=%v body=%s", createResp.StatusCode, err, createResp.Body)
+	}
+
+	var created models.HealthLog
+	if err := json.Unmarshal(decodeEnvelope(t, createResp).Data, &created); err != nil {
+		t.Fatalf("failed to decode created log: %v", err)
+	}
+
+	for _, field := range []string{"userId", "timestamp", "version", "unit"} {
+		patchBody, err := json.Marshal(map[string]interface{}{
+			"userId":    "user-1",
+			"timestamp": created.Timestamp,
+			"fields":    map[string]interface{}{field: "anything"},
+		})
+		if err != nil {
+			t.Fatalf("failed to build patch body for %q: %v", field, err)
+		}
+
+		resp, err := h.HandleRequest(ctx, authedRequest("PATCH", "/health-logs", string(patchBody)))
+		if err != nil {
+			t.Fatalf("unexpected error patching %q: %v", field, err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("patching %q: expected 400, got %d: %s", field, resp.StatusCode, resp.Body)
+		}
+	}
+}
+
+func TestHandlePatchHealthLog_RejectsValueOutsideSchema(t *testing.T) {
+	h := newTestHandler("user-1")
+	ctx := context.Background()
+
+	createResp, err := h.HandleRequest(ctx, authedRequest("POST", "/health-logs", `{"userId":"user-1","type":"heart_rate","value":70}`))
+	if err != nil || createResp.StatusCode != http.StatusCreated {
+		t.Fatalf("create failed: status=%d err=%v body=%s", createResp.StatusCode, err, createResp.Body)
+	}
+
+	var created models.HealthLog
+	if err := json.Unmarshal(decodeEnvelope(t, createResp).Data, &created); err != nil {
+		t.Fatalf("failed to decode created log: %v", err)
+	}
+
+	patchBody, err := json.Marshal(map[string]interface{}{
+		"userId":    "user-1",
+		"timestamp": created.Timestamp,
+		"fields":    map[string]interface{}{"value": 99999},
+	})
+	if err != nil {
+		t.Fatalf("failed to build patch body: %v", err)
+	}
+
+	resp, err := h.HandleRequest(ctx, authedRequest("PATCH", "/health-logs", string(patchBody)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an out-of-range heart_rate value, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestHandleGetHealthLogs_PaginationCursorRoundTrip(t *testing.T) {
+	h := newTestHandler("user-1")
+	ctx := context.Background()
+
+	// Each seeded log carries a distinct value since time.Now() has only
+	// second precision and rapid-fire creates in this loop could otherwise
+	// collide on the same Timestamp.
+	const total = 3
+	for i := 0; i < total; i++ {
+		body := fmt.Sprintf(`{"userId":"user-1","type":"steps","value":%d}`, 100+i)
+		resp, err := h.HandleRequest(ctx, authedRequest("POST", "/health-logs", body))
+		if err != nil || resp.StatusCode != http.StatusCreated {
+			t.Fatalf("seed create %d failed: status=%d err=%v", i, resp.StatusCode, err)
+		}
+	}
+
+	seen := map[string]bool{}
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > total {
+			t.Fatalf("pagination did not terminate after %d pages", pages)
+		}
+
+		req := authedRequest("GET", "/health-logs", "")
+		req.QueryStringParameters = map[string]string{"userId": "user-1", "limit": "1"}
+		if cursor != "" {
+			req.QueryStringParameters["cursor"] = cursor
+		}
+
+		resp, err := h.HandleRequest(ctx, req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("list failed: status=%d err=%v body=%s", resp.StatusCode, err, resp.Body)
+		}
+
+		env := decodeEnvelope(t, resp)
+		var page []models.HealthLog
+		if err := json.Unmarshal(env.Data, &page); err != nil {
+			t.Fatalf("failed to decode page: %v", err)
+		}
+		if len(page) != 1 {
+			t.Fatalf("expected 1 log per page, got %d", len(page))
+		}
+		seen[string(page[0].Value)] = true
+
+		if env.NextCursor == "" {
+			break
+		}
+		cursor = env.NextCursor
+	}
+
+	if len(seen) != total {
+		t.Errorf("expected %d distinct logs across pages, got %d", total, len(seen))
+	}
+}
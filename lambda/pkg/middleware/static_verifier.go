@@ -0,0 +1,19 @@
+package middleware
+
+import "context"
+
+// StaticVerifier is a Verifier that always returns a fixed set of claims (or
+// a fixed error), for unit tests and local development without a real JWKS
+// endpoint.
+type StaticVerifier struct {
+	Claims *Claims
+	Err    error
+}
+
+// Verify ignores tokenString and returns the configured Claims or Err.
+func (v *StaticVerifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	if v.Err != nil {
+		return nil, v.Err
+	}
+	return v.Claims, nil
+}
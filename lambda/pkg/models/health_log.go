@@ -1,8 +1,111 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Type identifies the kind of measurement a HealthLog records. Each Type has
+// a corresponding schema enforced by the validators package.
+type Type string
+
+const (
+	TypeHeartRate     Type = "heart_rate"
+	TypeBloodPressure Type = "blood_pressure"
+	TypeSteps         Type = "steps"
+	TypeSleep         Type = "sleep"
+	TypeWeight        Type = "weight"
+)
+
+// RawValue holds a health log's measurement value as arbitrary JSON (a
+// number, object, etc. depending on Type), while still storing it as a
+// native DynamoDB attribute rather than an opaque string or binary blob.
+type RawValue json.RawMessage
+
+// MarshalDynamoDBAttributeValue stores the value as its native DynamoDB
+// attribute type: a number (N) for a bare number, and a map (M) for an
+// object, so individual sub-fields (e.g. systolic/diastolic) stay queryable.
+func (v RawValue) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(v, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+
+	wrapped, err := attributevalue.Marshal(map[string]interface{}{"value": decoded})
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := wrapped.(*types.AttributeValueMemberM)
+	if !ok {
+		return nil, fmt.Errorf("unexpected attribute type for value")
+	}
+
+	return m.Value["value"], nil
+}
+
+// UnmarshalDynamoDBAttributeValue reads the value back from its native
+// DynamoDB representation. It also handles legacy records written before
+// this migration, whose value was a plain string (S), decoding them into a
+// JSON string so older data keeps working unchanged.
+func (v *RawValue) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	if s, ok := av.(*types.AttributeValueMemberS); ok {
+		encoded, err := json.Marshal(s.Value)
+		if err != nil {
+			return err
+		}
+		*v = RawValue(encoded)
+		return nil
+	}
+
+	var decoded interface{}
+	if err := attributevalue.Unmarshal(av, &decoded); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	*v = RawValue(encoded)
+	return nil
+}
+
+// MarshalJSON passes the raw value through unchanged.
+func (v RawValue) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// UnmarshalJSON stores the raw value unchanged.
+func (v *RawValue) UnmarshalJSON(data []byte) error {
+	*v = append((*v)[0:0], data...)
+	return nil
+}
+
+// HealthLog is a single health measurement for a user at a point in time.
 type HealthLog struct {
-	UserID    string `json:"userId" dynamodbav:"userId"`
-	Timestamp string `json:"timestamp" dynamodbav:"timestamp"`
-	Type      string `json:"type" dynamodbav:"type"`
-	Value     string `json:"value" dynamodbav:"value"`
+	UserID    string   `json:"userId" dynamodbav:"userId"`
+	Timestamp string   `json:"timestamp" dynamodbav:"timestamp"`
+	Type      Type     `json:"type" dynamodbav:"type"`
+	Value     RawValue `json:"value" dynamodbav:"value"`
+	Unit      string   `json:"unit,omitempty" dynamodbav:"unit,omitempty"`
+	Version   int64    `json:"version" dynamodbav:"version"`
+
+	// TypeUserKey is "<userId>#<type>", the partition key of the
+	// type-user-index GSI used to aggregate a single user's logs of one
+	// type without scanning the whole table. It is derived on write and
+	// never set directly by callers.
+	TypeUserKey string `json:"-" dynamodbav:"typeUserKey"`
+}
+
+// NewTypeUserKey builds the type-user-index partition key for a user and type.
+func NewTypeUserKey(userID string, logType Type) string {
+	return userID + "#" + string(logType)
 }
@@ -2,25 +2,159 @@ package stores
 
 import (
 	"context"
+	"errors"
+	"log"
+	"net/url"
 	"os"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	smithyendpoints "github.com/aws/smithy-go/endpoints"
 )
 
-func InitHealthLogStore(ctx context.Context) (*HealthLogStore, error) {
-	cfg, err := config.LoadDefaultConfig(ctx)
+// staticEndpointResolver routes all DynamoDB calls to a fixed endpoint,
+// used to point the client at a local LocalStack instance instead of AWS.
+type staticEndpointResolver struct {
+	URL string
+}
+
+func (r *staticEndpointResolver) ResolveEndpoint(_ context.Context, _ dynamodb.EndpointParameters) (smithyendpoints.Endpoint, error) {
+	uri, err := url.Parse(r.URL)
 	if err != nil {
-		return nil, err
+		return smithyendpoints.Endpoint{}, err
 	}
+	return smithyendpoints.Endpoint{URI: *uri}, nil
+}
 
-	client := dynamodb.NewFromConfig(cfg)
+// InitHealthLogStore builds a HealthLogStorer from the environment. In
+// production this targets real DynamoDB; for local development, setting
+// AWS_ENDPOINT_URL points the client at a LocalStack-style endpoint (e.g.
+// http://localhost:4566) so the Lambda can run end-to-end offline, and
+// STORAGE_BACKEND=memory swaps in an in-process store with no AWS
+// dependency at all, for unit tests and local `go run`.
+//
+// Recognized environment variables:
+//   - STORAGE_BACKEND: "memory" or "dynamodb" (default "dynamodb")
+//   - AWS_ENDPOINT_URL: overrides the DynamoDB endpoint (e.g. for LocalStack)
+//   - AWS_REGION: overrides the SDK-resolved region
+//   - AWS_PROFILE: selects a named profile from the shared AWS config
+//   - DYNAMODB_DISABLE_SSL: if "true" and AWS_ENDPOINT_URL has no scheme,
+//     defaults it to http:// instead of https://
+//   - DYNAMODB_TABLE_NAME: overrides the table name (defaults to
+//     "verve-health-logs")
+func InitHealthLogStore(ctx context.Context) (HealthLogStorer, error) {
+	if os.Getenv("STORAGE_BACKEND") == "memory" {
+		return NewMemoryHealthLogStore(), nil
+	}
 
-	tableName := os.Getenv("DYNAMODB_TABLE_NAME")
+	client, err := NewDynamoDBClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	tableName := os.Getenv("DYNAMODB_TABLE_NAME")
 	if tableName == "" {
 		tableName = "verve-health-logs"
 	}
 
-	return NewHealthLogStore(client, tableName), nil
+	// Best-effort: add the aggregation GSI on cold-start if an older table
+	// doesn't have it yet. Failures (e.g. missing UpdateTable permission)
+	// are logged, not fatal, since the rest of the API doesn't depend on it.
+	if err := ensureTypeUserIndex(ctx, client, tableName); err != nil {
+		log.Printf("failed to ensure %s index on table %s: %v", typeUserIndexName, tableName, err)
+	}
+
+	return NewDynamoHealthLogStore(client, tableName), nil
+}
+
+// ensureTypeUserIndex adds the type-user-index GSI to tableName if it is
+// missing, so Aggregate can query it without a manual migration step.
+func ensureTypeUserIndex(ctx context.Context, client *dynamodb.Client, tableName string) error {
+	description, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			// Table doesn't exist yet; cmd/migrate is responsible for
+			// creating it (with this index already included).
+			return nil
+		}
+		return err
+	}
+
+	for _, gsi := range description.Table.GlobalSecondaryIndexes {
+		if aws.ToString(gsi.IndexName) == typeUserIndexName {
+			return nil
+		}
+	}
+
+	_, err = client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+		TableName: aws.String(tableName),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("typeUserKey"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("timestamp"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+			{
+				Create: &types.CreateGlobalSecondaryIndexAction{
+					IndexName: aws.String(typeUserIndexName),
+					KeySchema: []types.KeySchemaElement{
+						{AttributeName: aws.String("typeUserKey"), KeyType: types.KeyTypeHash},
+						{AttributeName: aws.String("timestamp"), KeyType: types.KeyTypeRange},
+					},
+					Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+				},
+			},
+		},
+	})
+	return err
+}
+
+// NewDynamoDBClient builds a DynamoDB client from the environment,
+// respecting the same AWS_ENDPOINT_URL/AWS_REGION/AWS_PROFILE/
+// DYNAMODB_DISABLE_SSL overrides as InitHealthLogStore. It is exported
+// separately so tools like cmd/migrate can reuse it without a table name.
+func NewDynamoDBClient(ctx context.Context) (*dynamodb.Client, error) {
+	var opts []func(*config.LoadOptions) error
+
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+
+	endpointURL := os.Getenv("AWS_ENDPOINT_URL")
+	if endpointURL != "" {
+		if !strings.Contains(endpointURL, "://") {
+			scheme := "https://"
+			if os.Getenv("DYNAMODB_DISABLE_SSL") == "true" {
+				scheme = "http://"
+			}
+			endpointURL = scheme + endpointURL
+		}
+
+		// LocalStack doesn't validate credentials, but the SDK still
+		// requires something to sign requests with.
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider("local", "local", ""),
+		))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var dynamoOpts []func(*dynamodb.Options)
+	if endpointURL != "" {
+		dynamoOpts = append(dynamoOpts, dynamodb.WithEndpointResolverV2(&staticEndpointResolver{URL: endpointURL}))
+	}
+
+	return dynamodb.NewFromConfig(cfg, dynamoOpts...), nil
 }
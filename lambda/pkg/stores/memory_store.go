@@ -0,0 +1,280 @@
+package stores
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"lambda-func/pkg/models"
+)
+
+// MemoryHealthLogStore is a thread-safe, in-process HealthLogStorer with no
+// AWS dependency, for unit tests and local `go run` mode
+// (STORAGE_BACKEND=memory).
+type MemoryHealthLogStore struct {
+	mu   sync.RWMutex
+	logs []models.HealthLog // kept sorted by Timestamp ascending
+}
+
+// NewMemoryHealthLogStore returns an empty MemoryHealthLogStore.
+func NewMemoryHealthLogStore() *MemoryHealthLogStore {
+	return &MemoryHealthLogStore{}
+}
+
+// CreateHealthLog inserts a new health log, keeping logs sorted by timestamp.
+func (s *MemoryHealthLogStore) CreateHealthLog(ctx context.Context, healthLog *models.HealthLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	healthLog.TypeUserKey = models.NewTypeUserKey(healthLog.UserID, healthLog.Type)
+	s.insertLocked(*healthLog)
+	return nil
+}
+
+// BatchCreateHealthLogs inserts many logs at once. There's nothing to chunk
+// or retry in-process, so every item always succeeds.
+func (s *MemoryHealthLogStore) BatchCreateHealthLogs(ctx context.Context, healthLogs []models.HealthLog) ([]BatchCreateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]BatchCreateResult, len(healthLogs))
+	for i, log := range healthLogs {
+		log.TypeUserKey = models.NewTypeUserKey(log.UserID, log.Type)
+		s.insertLocked(log)
+		results[i] = BatchCreateResult{UserID: log.UserID, Timestamp: log.Timestamp, Success: true}
+	}
+	return results, nil
+}
+
+// GetHealthLog returns a specific log by userId and timestamp.
+func (s *MemoryHealthLogStore) GetHealthLog(ctx context.Context, userID, timestamp string) (*models.HealthLog, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.findLocked(userID, timestamp)
+	if i == -1 {
+		return nil, ErrHealthLogNotFound
+	}
+
+	log := s.logs[i]
+	return &log, nil
+}
+
+// ListHealthLogs mirrors DynamoHealthLogStore.ListHealthLogs: newest first,
+// optionally filtered by type and a [From, To] range, paginated via an
+// opaque cursor.
+func (s *MemoryHealthLogStore) ListHealthLogs(ctx context.Context, query ListHealthLogsQuery) ([]models.HealthLog, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []models.HealthLog
+	for i := len(s.logs) - 1; i >= 0; i-- {
+		log := s.logs[i]
+		if log.UserID != query.UserID {
+			continue
+		}
+		if query.Type != "" && string(log.Type) != query.Type {
+			continue
+		}
+		if query.From != "" && query.To != "" && (log.Timestamp < query.From || log.Timestamp > query.To) {
+			continue
+		}
+		matched = append(matched, log)
+	}
+
+	start := 0
+	if query.Cursor != "" {
+		offset, err := decodeMemoryCursor(query.Cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+		}
+		start = offset
+	}
+	if start > len(matched) {
+		start = len(matched)
+	}
+
+	limit := int(query.Limit)
+	if limit <= 0 {
+		limit = len(matched)
+	}
+	end := start + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	var nextCursor string
+	if end < len(matched) {
+		nextCursor = encodeMemoryCursor(end)
+	}
+
+	return matched[start:end], nextCursor, nil
+}
+
+// PatchHealthLog applies a partial update in place, mirroring the
+// attribute_exists/version-match semantics of DynamoHealthLogStore's
+// UpdateItem-based implementation.
+func (s *MemoryHealthLogStore) PatchHealthLog(ctx context.Context, userID, timestamp string, fields map[string]interface{}, expectedVersion *int64) (*models.HealthLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findLocked(userID, timestamp)
+	if i == -1 {
+		return nil, ErrHealthLogNotFound
+	}
+
+	current := s.logs[i]
+	if expectedVersion != nil && current.Version != *expectedVersion {
+		return nil, ErrVersionMismatch
+	}
+
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var asMap map[string]interface{}
+	if err := json.Unmarshal(raw, &asMap); err != nil {
+		return nil, err
+	}
+
+	for field, value := range fields {
+		if value == nil {
+			delete(asMap, field)
+			continue
+		}
+		asMap[field] = value
+	}
+	asMap["version"] = current.Version + 1
+
+	merged, err := json.Marshal(asMap)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated models.HealthLog
+	if err := json.Unmarshal(merged, &updated); err != nil {
+		return nil, err
+	}
+	updated.UserID = userID
+	updated.Timestamp = timestamp
+	updated.TypeUserKey = models.NewTypeUserKey(updated.UserID, updated.Type)
+
+	s.logs[i] = updated
+	result := updated
+	return &result, nil
+}
+
+// DeleteHealthLog removes a specific log, if present. Like DynamoDB's
+// DeleteItem, deleting a log that doesn't exist isn't an error.
+func (s *MemoryHealthLogStore) DeleteHealthLog(ctx context.Context, userID, timestamp string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findLocked(userID, timestamp)
+	if i == -1 {
+		return nil
+	}
+
+	s.logs = append(s.logs[:i], s.logs[i+1:]...)
+	return nil
+}
+
+// Aggregate buckets a user's logs of one type into day/week/month windows,
+// the same way DynamoHealthLogStore.Aggregate does against the
+// type-user-index GSI.
+func (s *MemoryHealthLogStore) Aggregate(ctx context.Context, query AggregateQuery) ([]AggregateBucket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accumulators := map[string]*aggregateAccumulator{}
+	var order []string
+
+	for _, log := range s.logs {
+		if log.UserID != query.UserID || string(log.Type) != query.Type {
+			continue
+		}
+		if query.From != "" && query.To != "" && (log.Timestamp < query.From || log.Timestamp > query.To) {
+			continue
+		}
+
+		value, ok := numericValue(log.Value)
+		if !ok {
+			continue
+		}
+
+		ts, err := time.Parse(time.RFC3339, log.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		bucketStart := truncateToBucket(ts.In(query.TZ), query.Bucket)
+		key := bucketStart.Format(time.RFC3339)
+
+		acc, exists := accumulators[key]
+		if !exists {
+			acc = &aggregateAccumulator{min: value, max: value}
+			accumulators[key] = acc
+			order = append(order, key)
+		}
+		acc.add(value)
+	}
+
+	sort.Strings(order)
+
+	buckets := make([]AggregateBucket, 0, len(order))
+	for _, key := range order {
+		acc := accumulators[key]
+		buckets = append(buckets, AggregateBucket{
+			BucketStart: key,
+			Count:       acc.count,
+			Min:         acc.min,
+			Max:         acc.max,
+			Sum:         acc.sum,
+			Avg:         acc.sum / float64(acc.count),
+		})
+	}
+
+	return buckets, nil
+}
+
+// insertLocked inserts log keeping s.logs sorted by Timestamp. Callers must
+// hold s.mu for writing.
+func (s *MemoryHealthLogStore) insertLocked(log models.HealthLog) {
+	i := sort.Search(len(s.logs), func(i int) bool { return s.logs[i].Timestamp >= log.Timestamp })
+	s.logs = append(s.logs, models.HealthLog{})
+	copy(s.logs[i+1:], s.logs[i:])
+	s.logs[i] = log
+}
+
+// findLocked returns the index of the log matching userID and timestamp, or
+// -1. Callers must hold s.mu for reading or writing.
+func (s *MemoryHealthLogStore) findLocked(userID, timestamp string) int {
+	for i, log := range s.logs {
+		if log.UserID == userID && log.Timestamp == timestamp {
+			return i
+		}
+	}
+	return -1
+}
+
+// encodeMemoryCursor and decodeMemoryCursor implement ListHealthLogs'
+// pagination as a plain offset into the filtered result set. This is
+// simpler than DynamoDB's LastEvaluatedKey cursor and fine for a store
+// meant for tests and local development, not concurrent production traffic.
+func encodeMemoryCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeMemoryCursor(cursor string) (int, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(raw))
+}
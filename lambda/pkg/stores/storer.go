@@ -0,0 +1,21 @@
+package stores
+
+import (
+	"context"
+
+	"lambda-func/pkg/models"
+)
+
+// HealthLogStorer is the storage contract HealthLogHandler depends on. It
+// is implemented by DynamoHealthLogStore for production and
+// MemoryHealthLogStore for unit tests and local `go run` mode, selected by
+// InitHealthLogStore via STORAGE_BACKEND.
+type HealthLogStorer interface {
+	CreateHealthLog(ctx context.Context, healthLog *models.HealthLog) error
+	BatchCreateHealthLogs(ctx context.Context, healthLogs []models.HealthLog) ([]BatchCreateResult, error)
+	GetHealthLog(ctx context.Context, userID, timestamp string) (*models.HealthLog, error)
+	ListHealthLogs(ctx context.Context, query ListHealthLogsQuery) ([]models.HealthLog, string, error)
+	PatchHealthLog(ctx context.Context, userID, timestamp string, fields map[string]interface{}, expectedVersion *int64) (*models.HealthLog, error)
+	DeleteHealthLog(ctx context.Context, userID, timestamp string) error
+	Aggregate(ctx context.Context, query AggregateQuery) ([]AggregateBucket, error)
+}
@@ -2,7 +2,14 @@ package stores
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -12,20 +19,36 @@ import (
 	"lambda-func/pkg/models"
 )
 
-type HealthLogStore struct {
+// ErrHealthLogNotFound is returned when an operation targets a log that
+// does not exist.
+var ErrHealthLogNotFound = errors.New("health log not found")
+
+// ErrVersionMismatch is returned when an expected version is provided (from
+// an If-Match header, or pinned by the handler) and does not match the
+// stored record, indicating a concurrent write.
+var ErrVersionMismatch = errors.New("health log version mismatch")
+
+// ErrInvalidCursor is returned when a caller-supplied pagination cursor
+// can't be decoded back into a start key, indicating bad client input
+// rather than a server-side failure.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type DynamoHealthLogStore struct {
 	client    *dynamodb.Client
 	tableName string
 }
 
-func NewHealthLogStore(client *dynamodb.Client, tableName string) *HealthLogStore {
-	return &HealthLogStore{
+func NewDynamoHealthLogStore(client *dynamodb.Client, tableName string) *DynamoHealthLogStore {
+	return &DynamoHealthLogStore{
 		client:    client,
 		tableName: tableName,
 	}
 }
 
 // CreateHealthLog inserts a new health log into DynamoDB
-func (s *HealthLogStore) CreateHealthLog(ctx context.Context, healthLog *models.HealthLog) error {
+func (s *DynamoHealthLogStore) CreateHealthLog(ctx context.Context, healthLog *models.HealthLog) error {
+	healthLog.TypeUserKey = models.NewTypeUserKey(healthLog.UserID, healthLog.Type)
+
 	item, err := attributevalue.MarshalMap(healthLog)
 	if err != nil {
 		return fmt.Errorf("failed to marshal: %w", err)
@@ -42,8 +65,123 @@ func (s *HealthLogStore) CreateHealthLog(ctx context.Context, healthLog *models.
 	return nil
 }
 
+// dynamoBatchWriteLimit is DynamoDB's hard cap on items per BatchWriteItem call.
+const dynamoBatchWriteLimit = 25
+
+// maxBatchWriteRetries bounds the number of retries for UnprocessedItems
+// before giving up on the remaining items.
+const maxBatchWriteRetries = 5
+
+// BatchCreateResult reports the outcome of one item within a
+// BatchCreateHealthLogs call.
+type BatchCreateResult struct {
+	UserID    string `json:"userId"`
+	Timestamp string `json:"timestamp"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchCreateHealthLogs writes many health logs using chunked
+// BatchWriteItem calls (25 items per DynamoDB request), retrying
+// UnprocessedItems with exponential backoff and jitter. It returns a
+// per-item result so callers can report partial failures.
+func (s *DynamoHealthLogStore) BatchCreateHealthLogs(ctx context.Context, healthLogs []models.HealthLog) ([]BatchCreateResult, error) {
+	results := make([]BatchCreateResult, len(healthLogs))
+	for i, log := range healthLogs {
+		results[i] = BatchCreateResult{UserID: log.UserID, Timestamp: log.Timestamp, Success: true}
+	}
+
+	for start := 0; start < len(healthLogs); start += dynamoBatchWriteLimit {
+		end := start + dynamoBatchWriteLimit
+		if end > len(healthLogs) {
+			end = len(healthLogs)
+		}
+		chunk := healthLogs[start:end]
+
+		writeRequests, err := buildPutRequests(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal batch: %w", err)
+		}
+
+		unprocessed, err := s.batchWriteWithRetry(ctx, writeRequests)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write batch: %w", err)
+		}
+
+		if len(unprocessed) > 0 {
+			failedKeys := make(map[string]bool, len(unprocessed))
+			for _, req := range unprocessed {
+				if req.PutRequest == nil {
+					continue
+				}
+				var log models.HealthLog
+				if err := attributevalue.UnmarshalMap(req.PutRequest.Item, &log); err != nil {
+					continue
+				}
+				failedKeys[log.UserID+"|"+log.Timestamp] = true
+			}
+			for i := start; i < end; i++ {
+				if failedKeys[healthLogs[i].UserID+"|"+healthLogs[i].Timestamp] {
+					results[i].Success = false
+					results[i].Error = "failed to write after retries"
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func buildPutRequests(logs []models.HealthLog) ([]types.WriteRequest, error) {
+	requests := make([]types.WriteRequest, 0, len(logs))
+	for _, log := range logs {
+		log.TypeUserKey = models.NewTypeUserKey(log.UserID, log.Type)
+
+		item, err := attributevalue.MarshalMap(log)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+	return requests, nil
+}
+
+// batchWriteWithRetry issues BatchWriteItem, retrying any UnprocessedItems
+// with exponential backoff and jitter. It returns whatever remains
+// unprocessed after exhausting retries.
+func (s *DynamoHealthLogStore) batchWriteWithRetry(ctx context.Context, requests []types.WriteRequest) ([]types.WriteRequest, error) {
+	pending := requests
+
+	for attempt := 0; attempt <= maxBatchWriteRetries && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * 100 * time.Millisecond
+			jitter := time.Duration(rand.Int63n(int64(backoff)))
+			select {
+			case <-time.After(backoff + jitter):
+			case <-ctx.Done():
+				return pending, ctx.Err()
+			}
+		}
+
+		result, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				s.tableName: pending,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		pending = result.UnprocessedItems[s.tableName]
+	}
+
+	return pending, nil
+}
+
 // GetHealthLog retrieves a specific health log by userId and timestamp
-func (s *HealthLogStore) GetHealthLog(ctx context.Context, userID, timestamp string) (*models.HealthLog, error) {
+func (s *DynamoHealthLogStore) GetHealthLog(ctx context.Context, userID, timestamp string) (*models.HealthLog, error) {
 	result, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(s.tableName),
 		Key: map[string]types.AttributeValue{
@@ -56,7 +194,7 @@ func (s *HealthLogStore) GetHealthLog(ctx context.Context, userID, timestamp str
 	}
 
 	if result.Item == nil {
-		return nil, fmt.Errorf("log not found")
+		return nil, ErrHealthLogNotFound
 	}
 
 	var healthLog models.HealthLog
@@ -68,86 +206,387 @@ func (s *HealthLogStore) GetHealthLog(ctx context.Context, userID, timestamp str
 	return &healthLog, nil
 }
 
-// GetHealthLogsByUserID retrieves all health logs for a specific user
-func (s *HealthLogStore) GetHealthLogsByUserID(ctx context.Context, userID string) ([]models.HealthLog, error) {
-	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(s.tableName),
-		KeyConditionExpression: aws.String("userId = :userId"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":userId": &types.AttributeValueMemberS{Value: userID},
-		},
-		ScanIndexForward: aws.Bool(false),
-	})
+// ListHealthLogsQuery describes a paginated, optionally filtered and
+// range-bounded listing of a user's health logs.
+type ListHealthLogsQuery struct {
+	UserID string
+	Type   string
+	From   string // RFC3339, inclusive
+	To     string // RFC3339, inclusive
+	Limit  int32
+	Cursor string
+}
+
+// paginationKey is the subset of a health log's key attributes encoded into
+// an opaque cursor so it can be round-tripped back into ExclusiveStartKey.
+type paginationKey struct {
+	UserID    string `json:"userId"`
+	Timestamp string `json:"timestamp"`
+}
+
+// ListHealthLogs retrieves a page of a user's health logs, optionally
+// filtered by type and bounded to a [From, To] timestamp range, returning an
+// opaque cursor to fetch the next page when more results are available.
+func (s *DynamoHealthLogStore) ListHealthLogs(ctx context.Context, query ListHealthLogsQuery) ([]models.HealthLog, string, error) {
+	keyCondition := "userId = :userId"
+	exprAttrValues := map[string]types.AttributeValue{
+		":userId": &types.AttributeValueMemberS{Value: query.UserID},
+	}
+	exprAttrNames := map[string]string{}
+
+	if query.From != "" && query.To != "" {
+		keyCondition += " AND #ts BETWEEN :from AND :to"
+		exprAttrNames["#ts"] = "timestamp"
+		exprAttrValues[":from"] = &types.AttributeValueMemberS{Value: query.From}
+		exprAttrValues[":to"] = &types.AttributeValueMemberS{Value: query.To}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: exprAttrValues,
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(query.Limit),
+	}
+	if len(exprAttrNames) > 0 {
+		input.ExpressionAttributeNames = exprAttrNames
+	}
+
+	if query.Type != "" {
+		if exprAttrNames["#ts"] == "" {
+			exprAttrNames = map[string]string{}
+			input.ExpressionAttributeNames = exprAttrNames
+		}
+		input.FilterExpression = aws.String("#type = :type")
+		input.ExpressionAttributeNames["#type"] = "type"
+		exprAttrValues[":type"] = &types.AttributeValueMemberS{Value: query.Type}
+	}
+
+	if query.Cursor != "" {
+		startKey, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+		}
+		input.ExclusiveStartKey = startKey
+	}
+
+	result, err := s.client.Query(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get logs: %w", err)
+		return nil, "", fmt.Errorf("failed to list logs: %w", err)
 	}
 
-	var healthLogs []models.HealthLog
+	var logs []models.HealthLog
 	for _, item := range result.Items {
-		var healthLog models.HealthLog
-		err = attributevalue.UnmarshalMap(item, &healthLog)
+		var log models.HealthLog
+		if err := attributevalue.UnmarshalMap(item, &log); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	var nextCursor string
+	if len(result.LastEvaluatedKey) > 0 {
+		nextCursor, err = encodeCursor(result.LastEvaluatedKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal: %w", err)
+			return nil, "", fmt.Errorf("failed to encode cursor: %w", err)
 		}
-		healthLogs = append(healthLogs, healthLog)
 	}
 
-	return healthLogs, nil
+	return logs, nextCursor, nil
 }
 
-// GetHealthLogsByUserIDAndType retrieves logs for a user filtered by type
-func (s *HealthLogStore) GetHealthLogsByUserIDAndType(ctx context.Context, userID, logType string) ([]models.HealthLog, error) {
-	result, err := s.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(s.tableName),
-		KeyConditionExpression: aws.String("userId = :userId"),
-		FilterExpression:       aws.String("#type = :type"),
-		ExpressionAttributeNames: map[string]string{
-			"#type": "type",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":userId": &types.AttributeValueMemberS{Value: userID},
-			":type":   &types.AttributeValueMemberS{Value: logType},
-		},
-		ScanIndexForward: aws.Bool(false),
-	})
+// encodeCursor serializes a DynamoDB LastEvaluatedKey into an opaque,
+// base64-encoded JSON string suitable for returning to API callers.
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	var pk paginationKey
+	if err := attributevalue.UnmarshalMap(key, &pk); err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(pk)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get logs by type: %w", err)
+		return "", err
 	}
 
-	var logs []models.HealthLog
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
 
-	for _, item := range result.Items {
-		var log models.HealthLog
-		err = attributevalue.UnmarshalMap(item, &log)
+// decodeCursor reverses encodeCursor, producing a DynamoDB key map usable as
+// ExclusiveStartKey.
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var pk paginationKey
+	if err := json.Unmarshal(raw, &pk); err != nil {
+		return nil, err
+	}
+
+	return map[string]types.AttributeValue{
+		"userId":    &types.AttributeValueMemberS{Value: pk.UserID},
+		"timestamp": &types.AttributeValueMemberS{Value: pk.Timestamp},
+	}, nil
+}
+
+// typeUserIndexName is the GSI keyed on (typeUserKey, timestamp) that
+// Aggregate queries, so rollups don't require a table scan.
+const typeUserIndexName = "type-user-index"
+
+// AggregateQuery describes a bucketed rollup of one user's health logs of a
+// single type over an optional time range.
+type AggregateQuery struct {
+	UserID string
+	Type   string
+	Bucket string // "day", "week", or "month"
+	From   string // RFC3339, inclusive
+	To     string // RFC3339, inclusive
+	TZ     *time.Location
+}
+
+// AggregateBucket is one time bucket's rollup.
+type AggregateBucket struct {
+	BucketStart string  `json:"bucketStart"`
+	Count       int     `json:"count"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Sum         float64 `json:"sum"`
+	Avg         float64 `json:"avg"`
+}
+
+// Aggregate buckets a user's health logs of one type into day/week/month
+// windows, computing count/min/max/sum/avg per bucket. It queries the
+// type-user-index GSI (partitioned on userId#type) and pages through all
+// matching items, accumulating in memory.
+func (s *DynamoHealthLogStore) Aggregate(ctx context.Context, query AggregateQuery) ([]AggregateBucket, error) {
+	typeUserKey := models.NewTypeUserKey(query.UserID, models.Type(query.Type))
+
+	keyCondition := "typeUserKey = :key"
+	exprAttrValues := map[string]types.AttributeValue{
+		":key": &types.AttributeValueMemberS{Value: typeUserKey},
+	}
+	exprAttrNames := map[string]string{"#ts": "timestamp"}
+	if query.From != "" && query.To != "" {
+		keyCondition += " AND #ts BETWEEN :from AND :to"
+		exprAttrValues[":from"] = &types.AttributeValueMemberS{Value: query.From}
+		exprAttrValues[":to"] = &types.AttributeValueMemberS{Value: query.To}
+	}
+
+	accumulators := map[string]*aggregateAccumulator{}
+	var order []string
+
+	var exclusiveStartKey map[string]types.AttributeValue
+	for {
+		result, err := s.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(s.tableName),
+			IndexName:                 aws.String(typeUserIndexName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeNames:  exprAttrNames,
+			ExpressionAttributeValues: exprAttrValues,
+			ExclusiveStartKey:         exclusiveStartKey,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal: %w", err)
+			return nil, fmt.Errorf("failed to query type-user-index: %w", err)
 		}
-		logs = append(logs, log)
+
+		for _, item := range result.Items {
+			var log models.HealthLog
+			if err := attributevalue.UnmarshalMap(item, &log); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal: %w", err)
+			}
+
+			value, ok := numericValue(log.Value)
+			if !ok {
+				continue
+			}
+
+			ts, err := time.Parse(time.RFC3339, log.Timestamp)
+			if err != nil {
+				continue
+			}
+
+			bucketStart := truncateToBucket(ts.In(query.TZ), query.Bucket)
+			key := bucketStart.Format(time.RFC3339)
+
+			acc, exists := accumulators[key]
+			if !exists {
+				acc = &aggregateAccumulator{min: value, max: value}
+				accumulators[key] = acc
+				order = append(order, key)
+			}
+			acc.add(value)
+		}
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		exclusiveStartKey = result.LastEvaluatedKey
 	}
 
-	return logs, nil
+	sort.Strings(order)
+
+	buckets := make([]AggregateBucket, 0, len(order))
+	for _, key := range order {
+		acc := accumulators[key]
+		buckets = append(buckets, AggregateBucket{
+			BucketStart: key,
+			Count:       acc.count,
+			Min:         acc.min,
+			Max:         acc.max,
+			Sum:         acc.sum,
+			Avg:         acc.sum / float64(acc.count),
+		})
+	}
+
+	return buckets, nil
 }
 
-// UpdateHealthLog updates an existing log
-func (s *HealthLogStore) UpdateHealthLog(ctx context.Context, healthLog *models.HealthLog) error {
-	item, err := attributevalue.MarshalMap(healthLog)
-	if err != nil {
-		return fmt.Errorf("failed to marshal: %w", err)
+// aggregateAccumulator tracks running count/min/max/sum for one bucket.
+type aggregateAccumulator struct {
+	count int
+	min   float64
+	max   float64
+	sum   float64
+}
+
+func (a *aggregateAccumulator) add(value float64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+	if a.count == 0 || value > a.max {
+		a.max = value
 	}
+	a.sum += value
+	a.count++
+}
 
-	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+// numericValue extracts a float64 from a health log's raw value, for Types
+// whose schema is a bare number (heart_rate, steps, sleep, weight).
+// Object-shaped values (e.g. blood_pressure) aren't aggregatable this way
+// and are skipped.
+func numericValue(raw models.RawValue) (float64, bool) {
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// truncateToBucket floors t to the start of its day/week/month bucket in
+// t's own location. Calendar buckets (week, month) don't have a fixed
+// duration, so this zeroes components directly rather than using
+// time.Truncate, which only supports fixed-size durations.
+func truncateToBucket(t time.Time, bucket string) time.Time {
+	switch bucket {
+	case "week":
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return d.AddDate(0, 0, -daysSinceMonday)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default: // "day"
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// PatchHealthLog applies a partial update to an existing log via a DynamoDB
+// UpdateItem, rather than rewriting the whole record with PutItem. It fails
+// with ErrHealthLogNotFound if the log does not exist, and with
+// ErrVersionMismatch if expectedVersion is set and does not match the
+// stored version (optimistic concurrency via the If-Match header). When
+// expectedVersion is set, a log that does not exist also fails the condition
+// and is reported as ErrVersionMismatch.
+func (s *DynamoHealthLogStore) PatchHealthLog(ctx context.Context, userID, timestamp string, fields map[string]interface{}, expectedVersion *int64) (*models.HealthLog, error) {
+	setClauses := []string{"#version = if_not_exists(#version, :zero) + :one"}
+	removeClauses := []string{}
+	exprAttrNames := map[string]string{
+		"#ts":      "timestamp",
+		"#version": "version",
+	}
+	exprAttrValues := map[string]types.AttributeValue{
+		":zero": &types.AttributeValueMemberN{Value: "0"},
+		":one":  &types.AttributeValueMemberN{Value: "1"},
+	}
+
+	i := 0
+	for field, value := range fields {
+		nameKey := fmt.Sprintf("#f%d", i)
+		exprAttrNames[nameKey] = field
+
+		if value == nil {
+			removeClauses = append(removeClauses, nameKey)
+			i++
+			continue
+		}
+
+		valueKey := fmt.Sprintf(":v%d", i)
+		av, err := attributevalue.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal field %q: %w", field, err)
+		}
+		exprAttrValues[valueKey] = av
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", nameKey, valueKey))
+		i++
+
+		// Patching type moves the log to a different type-user-index
+		// partition, so typeUserKey must be recomputed along with it or
+		// Aggregate will silently query the wrong (stale) partition.
+		if field == "type" {
+			newType, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("field %q must be a string", field)
+			}
+			exprAttrNames["#typeUserKey"] = "typeUserKey"
+			exprAttrValues[":typeUserKey"] = &types.AttributeValueMemberS{Value: models.NewTypeUserKey(userID, models.Type(newType))}
+			setClauses = append(setClauses, "#typeUserKey = :typeUserKey")
+		}
+	}
+
+	updateExpression := "SET " + strings.Join(setClauses, ", ")
+	if len(removeClauses) > 0 {
+		updateExpression += " REMOVE " + strings.Join(removeClauses, ", ")
+	}
+
+	conditionExpression := "attribute_exists(userId) AND attribute_exists(#ts)"
+	if expectedVersion != nil {
+		conditionExpression += " AND #version = :expectedVersion"
+		exprAttrValues[":expectedVersion"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", *expectedVersion)}
+	}
+
+	result, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(s.tableName),
-		Item:      item,
+		Key: map[string]types.AttributeValue{
+			"userId":    &types.AttributeValueMemberS{Value: userID},
+			"timestamp": &types.AttributeValueMemberS{Value: timestamp},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String(conditionExpression),
+		ExpressionAttributeNames:  exprAttrNames,
+		ExpressionAttributeValues: exprAttrValues,
+		ReturnValues:              types.ReturnValueAllNew,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update log: %w", err)
+		var conditionFailed *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionFailed) {
+			if expectedVersion != nil {
+				return nil, ErrVersionMismatch
+			}
+			return nil, ErrHealthLogNotFound
+		}
+		return nil, fmt.Errorf("failed to patch log: %w", err)
 	}
 
-	return nil
+	var healthLog models.HealthLog
+	if err := attributevalue.UnmarshalMap(result.Attributes, &healthLog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+
+	return &healthLog, nil
 }
 
 // DeleteHealthLog deletes a specific log
-func (s *HealthLogStore) DeleteHealthLog(ctx context.Context, userID, timestamp string) error {
+func (s *DynamoHealthLogStore) DeleteHealthLog(ctx context.Context, userID, timestamp string) error {
 	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(s.tableName),
 		Key: map[string]types.AttributeValue{
@@ -0,0 +1,110 @@
+// Package validators enforces the per-Type value schema for health logs, so
+// the store holds real, typed health data instead of a generic KV bag.
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"lambda-func/pkg/models"
+)
+
+// Schema describes the accepted shape for a health log Type's value, for
+// reporting back to callers that submit an unrecognized type.
+type Schema struct {
+	Type   models.Type `json:"type"`
+	Unit   string      `json:"unit"`
+	Min    *float64    `json:"min,omitempty"`
+	Max    *float64    `json:"max,omitempty"`
+	Fields []string    `json:"fields,omitempty"` // required sub-fields for object-shaped values
+}
+
+// Validator checks a raw JSON value against its Type's schema, returning
+// the canonical unit to store alongside it.
+type Validator func(raw json.RawMessage) (unit string, err error)
+
+type registryEntry struct {
+	schema    Schema
+	validator Validator
+}
+
+var registry = map[models.Type]registryEntry{
+	models.TypeHeartRate: {
+		schema:    Schema{Type: models.TypeHeartRate, Unit: "bpm", Min: floatPtr(20), Max: floatPtr(300)},
+		validator: numericRangeValidator("bpm", 20, 300),
+	},
+	models.TypeWeight: {
+		schema:    Schema{Type: models.TypeWeight, Unit: "kg", Min: floatPtr(1), Max: floatPtr(500)},
+		validator: numericRangeValidator("kg", 1, 500),
+	},
+	models.TypeSteps: {
+		schema:    Schema{Type: models.TypeSteps, Unit: "count", Min: floatPtr(0), Max: floatPtr(200000)},
+		validator: numericRangeValidator("count", 0, 200000),
+	},
+	models.TypeSleep: {
+		schema:    Schema{Type: models.TypeSleep, Unit: "minutes", Min: floatPtr(0), Max: floatPtr(1440)},
+		validator: numericRangeValidator("minutes", 0, 1440),
+	},
+	models.TypeBloodPressure: {
+		schema:    Schema{Type: models.TypeBloodPressure, Unit: "mmHg", Fields: []string{"systolic", "diastolic"}},
+		validator: bloodPressureValidator,
+	},
+}
+
+// IsRegistered reports whether typ has a known schema.
+func IsRegistered(typ models.Type) bool {
+	_, ok := registry[typ]
+	return ok
+}
+
+// Validate looks up the validator for typ and runs it against raw,
+// returning the unit to persist alongside the value. Callers should check
+// IsRegistered first to distinguish an unknown type from an invalid value.
+func Validate(typ models.Type, raw json.RawMessage) (unit string, err error) {
+	entry, ok := registry[typ]
+	if !ok {
+		return "", fmt.Errorf("unknown type %q", typ)
+	}
+	return entry.validator(raw)
+}
+
+// Schemas returns the accepted schema for every registered Type.
+func Schemas() []Schema {
+	schemas := make([]Schema, 0, len(registry))
+	for _, entry := range registry {
+		schemas = append(schemas, entry.schema)
+	}
+	return schemas
+}
+
+func numericRangeValidator(unit string, min, max float64) Validator {
+	return func(raw json.RawMessage) (string, error) {
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("value must be a number: %w", err)
+		}
+		if value < min || value > max {
+			return "", fmt.Errorf("value must be between %g and %g %s", min, max, unit)
+		}
+		return unit, nil
+	}
+}
+
+func bloodPressureValidator(raw json.RawMessage) (string, error) {
+	var value struct {
+		Systolic  *float64 `json:"systolic"`
+		Diastolic *float64 `json:"diastolic"`
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("value must be an object with systolic and diastolic: %w", err)
+	}
+	if value.Systolic == nil || value.Diastolic == nil {
+		return "", fmt.Errorf("systolic and diastolic are required")
+	}
+	if *value.Systolic <= 0 || *value.Systolic > 300 || *value.Diastolic <= 0 || *value.Diastolic > 200 {
+		return "", fmt.Errorf("systolic/diastolic out of range")
+	}
+	return "mmHg", nil
+}
+
+func floatPtr(f float64) *float64 { return &f }